@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Config holds the settings that used to be hardcoded as communityPrefix and
+// isBot's switch statement, so the tool can be reused by orgs other than
+// beyondstorage without a fork.
+type Config struct {
+	// CommunityPrefix is prepended to a header name to build its repo link,
+	// e.g. "https://github.com/beyondstorage/".
+	CommunityPrefix string `mapstructure:"community_prefix"`
+
+	// Bots lists usernames (with the leading "@") to skip when walking a
+	// report, glob patterns like "@*[bot]" are supported.
+	Bots []string `mapstructure:"bots"`
+
+	// RepoLinks overrides the link used for specific headers instead of
+	// CommunityPrefix + header, keyed by header name.
+	RepoLinks map[string]string `mapstructure:"repo_links"`
+
+	// Categories groups entries by label for renderers that support it,
+	// matched in order against an entry's labels.
+	Categories []CategoryRule `mapstructure:"categories"`
+}
+
+// CategoryRule groups entries whose labels intersect Labels under Name.
+type CategoryRule struct {
+	Name   string   `mapstructure:"name"`
+	Labels []string `mapstructure:"labels"`
+}
+
+// defaultConfig mirrors the tool's previous hardcoded behavior, used when
+// --config is not set.
+func defaultConfig() *Config {
+	return &Config{
+		CommunityPrefix: communityPrefix,
+		Bots:            []string{"@dependabot", "@BeyondRobot"},
+	}
+}
+
+// loadConfig reads --config (YAML or TOML, detected from its extension) via
+// viper, falling back to defaultConfig() when path is empty.
+func loadConfig(path string) (*Config, error) {
+	if path == "" {
+		return defaultConfig(), nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("read --config <%s> failed: [%w]", path, err)
+	}
+
+	cfg := defaultConfig()
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("parse --config <%s> failed: [%w]", path, err)
+	}
+	return cfg, nil
+}
+
+// isBot reports whether name (e.g. "@dependabot[bot]") matches one of cfg's
+// bot patterns, supporting glob syntax like "*[bot]".
+func (cfg *Config) isBot(name string) bool {
+	for _, pattern := range cfg.Bots {
+		if ok, _ := filepath.Match(escapeGlobBrackets(pattern), name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// globBracketEscaper backslash-escapes "[" and "]" so filepath.Match treats
+// them as literal characters instead of a character class. GitHub's actual
+// bot suffix is the literal string "[bot]" (e.g. "@dependabot[bot]"), which
+// filepath.Match would otherwise parse as "one of b/o/t", silently matching
+// unrelated names like "@bob" while missing every real bot account.
+var globBracketEscaper = strings.NewReplacer("[", `\[`, "]", `\]`)
+
+// escapeGlobBrackets rewrites "[" and "]" in pattern so filepath.Match
+// matches them literally; see globBracketEscaper.
+func escapeGlobBrackets(pattern string) string {
+	return globBracketEscaper.Replace(pattern)
+}
+
+// repoLink returns the link to use for header, honoring RepoLinks overrides.
+func (cfg *Config) repoLink(header string) string {
+	if link, ok := cfg.RepoLinks[header]; ok {
+		return link
+	}
+	return cfg.CommunityPrefix + header
+}
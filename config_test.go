@@ -0,0 +1,23 @@
+package main
+
+import "testing"
+
+func TestConfigIsBot(t *testing.T) {
+	cfg := &Config{Bots: []string{"@dependabot", "@BeyondRobot", "*[bot]"}}
+
+	cases := map[string]bool{
+		"@dependabot":          true,
+		"@BeyondRobot":         true,
+		"@dependabot[bot]":     true,
+		"@renovate[bot]":       true,
+		"@github-actions[bot]": true,
+		"@bob":                 false,
+		"@alice":               false,
+	}
+
+	for name, want := range cases {
+		if got := cfg.isBot(name); got != want {
+			t.Errorf("isBot(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
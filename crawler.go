@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/cheggaaa/pb/v3"
+)
+
+// flags for the multi-week crawler
+var fromWeek, toWeek string
+var concurrency int
+var noProgress, silent bool
+
+// weekRange expands [from, to] into the list of week identifiers (YYYY-MM-DD,
+// one per week) the crawler should fetch, inclusive of both ends.
+func weekRange(from, to string) ([]string, error) {
+	start, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --from %q: %w", from, err)
+	}
+	end, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --to %q: %w", to, err)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("--to %q is before --from %q", to, from)
+	}
+
+	var weeks []string
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 7) {
+		weeks = append(weeks, d.Format("2006-01-02"))
+	}
+	return weeks, nil
+}
+
+// weekResult is one worker's parse of a single week's report page.
+type weekResult struct {
+	week              string
+	headers           []string
+	headerContentDict map[string][]Entry
+	userDict          map[string]string
+	userStats         map[string]*UserStats
+	counters          Counters
+	err               error
+}
+
+// crawlWeeklyReports fetches and parses one report per week in inputTemplate
+// (with "{week}" substituted for each entry in weeks), using a worker pool of
+// concurrency goroutines, then merges the per-week results into a single
+// aggregated report. It aborts in-flight requests and returns partial results
+// on SIGINT.
+func crawlWeeklyReports(inputTemplate string, weeks []string, concurrency int, showProgress bool) (headers []string, headerContentDict map[string][]Entry, userDict map[string]string, userStats map[string]*UserStats, counters Counters) {
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	go func() {
+		if _, ok := <-sigCh; ok {
+			log.Println("interrupted, aborting in-flight requests and printing partial results")
+			cancel()
+		}
+	}()
+	defer signal.Stop(sigCh)
+
+	var bar *pb.ProgressBar
+	if showProgress && !silent {
+		bar = pb.StartNew(len(weeks))
+	}
+
+	weekCh := make(chan string)
+	resultCh := make(chan weekResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for week := range weekCh {
+				resultCh <- fetchWeek(ctx, inputTemplate, week)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(weekCh)
+		for _, week := range weeks {
+			select {
+			case weekCh <- week:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(resultCh)
+	}()
+
+	headerContentDict = make(map[string][]Entry)
+	userDict = make(map[string]string)
+	userStats = make(map[string]*UserStats)
+	seenHeader := make(map[string]bool)
+
+	for res := range resultCh {
+		if bar != nil {
+			bar.Increment()
+		}
+		if res.err != nil {
+			if !silent {
+				log.Printf("fetch week <%s> failed: [%v]", res.week, res.err)
+			}
+			continue
+		}
+		mergeWeekResult(res, &headers, headerContentDict, userDict, userStats, &counters, seenHeader)
+	}
+
+	if bar != nil {
+		bar.Finish()
+	}
+
+	return headers, headerContentDict, userDict, userStats, counters
+}
+
+// fetchWeek fetches and parses a single week's report page, respecting ctx
+// cancellation.
+func fetchWeek(ctx context.Context, inputTemplate, week string) weekResult {
+	if ctx.Err() != nil {
+		return weekResult{week: week, err: ctx.Err()}
+	}
+
+	input := strings.ReplaceAll(inputTemplate, "{week}", week)
+	reader, err := openInputCtx(ctx, input)
+	if err != nil {
+		return weekResult{week: week, err: err}
+	}
+	if closer, ok := reader.(interface{ Close() error }); ok {
+		defer closer.Close()
+	}
+
+	headers, headerContentDict, userDict, userStats, counters, err := parseWikiReport(reader)
+	if err != nil {
+		return weekResult{week: week, err: err}
+	}
+
+	return weekResult{week: week, headers: headers, headerContentDict: headerContentDict, userDict: userDict, userStats: userStats, counters: counters}
+}
+
+// mergeWeekResult folds one week's parsed report into the running aggregate,
+// appending new headers in first-seen order, concatenating entries for
+// headers repeated across weeks, and summing per-user stats and counters
+// across weeks. Each weekResult's counters come from its own parseWikiReport
+// call, so merging them here (rather than tallying into shared globals from
+// the worker goroutines) keeps the crawler's worker pool race-free.
+func mergeWeekResult(res weekResult, headers *[]string, headerContentDict map[string][]Entry, userDict map[string]string, userStats map[string]*UserStats, counters *Counters, seenHeader map[string]bool) {
+	for _, header := range res.headers {
+		if !seenHeader[header] {
+			seenHeader[header] = true
+			*headers = append(*headers, header)
+		}
+		headerContentDict[header] = append(headerContentDict[header], res.headerContentDict[header]...)
+	}
+	for user, link := range res.userDict {
+		userDict[user] = link
+	}
+	for user, stats := range res.userStats {
+		if _, ok := userStats[user]; !ok {
+			userStats[user] = &UserStats{}
+		}
+		userStats[user].Add(*stats)
+	}
+	counters.Add(res.counters)
+}
+
+// generateRangeReport crawls every week in [from, to] against the --input
+// template (which should contain a "{week}" placeholder) and writes the
+// merged report to output.
+func generateRangeReport(inputTemplate, from, to, output string) {
+	weeks, err := weekRange(from, to)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var writer io.Writer
+	if output == "" {
+		writer = os.Stdout
+	} else {
+		f, err := os.Create(output)
+		if err != nil {
+			log.Fatalf("create output file <%s> failed: [%v]", output, err)
+		}
+		writer = f
+	}
+
+	headers, headerContentDict, userDict, userStats, counters := crawlWeeklyReports(inputTemplate, weeks, concurrency, !noProgress)
+	renderReport(writer, buildReport(headers, headerContentDict, userDict, userStats, counters, nil, nil))
+}
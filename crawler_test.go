@@ -0,0 +1,75 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWeekRange(t *testing.T) {
+	got, err := weekRange("2024-01-01", "2024-01-15")
+	if err != nil {
+		t.Fatalf("weekRange returned error: %v", err)
+	}
+	want := []string{"2024-01-01", "2024-01-08", "2024-01-15"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("weekRange(2024-01-01, 2024-01-15) = %v, want %v", got, want)
+	}
+
+	if _, err := weekRange("2024-01-15", "2024-01-01"); err == nil {
+		t.Error("weekRange with --to before --from: want error, got nil")
+	}
+
+	if _, err := weekRange("not-a-date", "2024-01-01"); err == nil {
+		t.Error("weekRange with invalid --from: want error, got nil")
+	}
+}
+
+func TestMergeWeekResult(t *testing.T) {
+	headers := []string{"go-storage"}
+	headerContentDict := map[string][]Entry{
+		"go-storage": {{User: "@alice", Verb: "opened issue", Title: "week1 issue", Link: "u1"}},
+	}
+	userDict := map[string]string{"@alice": "https://github.com/alice"}
+	userStats := map[string]*UserStats{"@alice": {OpenedIssues: 1}}
+	counters := Counters{IssueOpen: 1}
+	seenHeader := map[string]bool{"go-storage": true}
+
+	res := weekResult{
+		week:    "2024-01-08",
+		headers: []string{"go-storage", "go-service-fs"},
+		headerContentDict: map[string][]Entry{
+			"go-storage":    {{User: "@bob", Verb: "closed issue", Title: "week2 issue", Link: "u2"}},
+			"go-service-fs": {{User: "@bob", Verb: "opened pull request", Title: "week2 pr", Link: "u3"}},
+		},
+		userDict:  map[string]string{"@bob": "https://github.com/bob"},
+		userStats: map[string]*UserStats{"@alice": {ClosedIssues: 1}, "@bob": {OpenedPRs: 1}},
+		counters:  Counters{IssueClose: 1, PROpen: 1},
+	}
+
+	mergeWeekResult(res, &headers, headerContentDict, userDict, userStats, &counters, seenHeader)
+
+	wantHeaders := []string{"go-storage", "go-service-fs"}
+	if !reflect.DeepEqual(headers, wantHeaders) {
+		t.Errorf("headers = %v, want %v", headers, wantHeaders)
+	}
+
+	if len(headerContentDict["go-storage"]) != 2 {
+		t.Errorf("headerContentDict[go-storage] = %v, want 2 entries", headerContentDict["go-storage"])
+	}
+	if len(headerContentDict["go-service-fs"]) != 1 {
+		t.Errorf("headerContentDict[go-service-fs] = %v, want 1 entry", headerContentDict["go-service-fs"])
+	}
+
+	if userDict["@bob"] != "https://github.com/bob" {
+		t.Errorf("userDict[@bob] = %q, want merged in", userDict["@bob"])
+	}
+
+	if got := userStats["@alice"]; got.OpenedIssues != 1 || got.ClosedIssues != 1 {
+		t.Errorf("userStats[@alice] = %+v, want OpenedIssues=1 ClosedIssues=1 (summed across weeks)", got)
+	}
+
+	wantCounters := Counters{IssueOpen: 1, IssueClose: 1, PROpen: 1}
+	if counters != wantCounters {
+		t.Errorf("counters = %+v, want %+v", counters, wantCounters)
+	}
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// githubAPIBase is the root of GitHub's REST API.
+const githubAPIBase = "https://api.github.com"
+
+// ghRepo is the subset of the GitHub repo payload we care about.
+type ghRepo struct {
+	Name string `json:"name"`
+}
+
+// ghUser is the subset of the GitHub user payload we care about.
+type ghUser struct {
+	Login   string `json:"login"`
+	HTMLURL string `json:"html_url"`
+}
+
+// ghIssue is the subset of the GitHub issue/PR payload we care about.
+// GitHub represents PRs as issues with a non-nil PullRequest field.
+type ghIssue struct {
+	Title       string    `json:"title"`
+	HTMLURL     string    `json:"html_url"`
+	User        ghUser    `json:"user"`
+	Labels      []ghLabel `json:"labels"`
+	PullRequest *struct {
+		MergedAt *time.Time `json:"merged_at"`
+	} `json:"pull_request"`
+}
+
+// ghLabel is the subset of the GitHub label payload we care about.
+type ghLabel struct {
+	Name string `json:"name"`
+}
+
+// ghClient talks to the GitHub REST API, handling auth and rate limits.
+type ghClient struct {
+	token      string
+	httpClient *http.Client
+}
+
+// newGHClient builds a client using GITHUB_TOKEN from the environment, if set.
+func newGHClient() *ghClient {
+	return &ghClient{
+		token:      os.Getenv("GITHUB_TOKEN"),
+		httpClient: &http.Client{},
+	}
+}
+
+// do sends req, backing off and retrying when GitHub reports the request was
+// rate-limited via X-RateLimit-Remaining/Retry-After.
+func (c *ghClient) do(req *http.Request) (*http.Response, error) {
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+
+	for {
+		res, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		// GitHub signals the primary rate limit via X-RateLimit-Remaining: 0,
+		// but the search API's secondary/abuse-detection limit responds 403
+		// with only a Retry-After header, so back off on either.
+		if res.StatusCode == http.StatusForbidden || res.StatusCode == http.StatusTooManyRequests {
+			if res.Header.Get("X-RateLimit-Remaining") == "0" || res.Header.Get("Retry-After") != "" {
+				wait := retryAfter(res)
+				log.Printf("github rate limit hit, sleeping %s before retry", wait)
+				res.Body.Close()
+				time.Sleep(wait)
+				continue
+			}
+		}
+
+		return res, nil
+	}
+}
+
+// retryAfter figures out how long to back off from Retry-After or the
+// X-RateLimit-Reset timestamp, falling back to a minute.
+func retryAfter(res *http.Response) time.Duration {
+	if ra := res.Header.Get("Retry-After"); ra != "" {
+		if secs, err := strconv.Atoi(ra); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+	}
+	if reset := res.Header.Get("X-RateLimit-Reset"); reset != "" {
+		if ts, err := strconv.ParseInt(reset, 10, 64); err == nil {
+			if d := time.Until(time.Unix(ts, 0)); d > 0 {
+				return d
+			}
+		}
+	}
+	return time.Minute
+}
+
+// getJSON issues a GET request against the GitHub API and decodes the JSON
+// body into v, returning the response so callers can inspect its headers.
+func (c *ghClient) getJSON(u string, v interface{}) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(res.Body)
+		return res, fmt.Errorf("github api error: %s %s: %s", res.Status, u, body)
+	}
+
+	return res, json.NewDecoder(res.Body).Decode(v)
+}
+
+// nextPageURL extracts the "next" link from a GitHub Link header, if any.
+func nextPageURL(res *http.Response) string {
+	for _, part := range strings.Split(res.Header.Get("Link"), ",") {
+		segs := strings.Split(strings.TrimSpace(part), ";")
+		if len(segs) == 2 && strings.TrimSpace(segs[1]) == `rel="next"` {
+			return strings.Trim(strings.TrimSpace(segs[0]), "<>")
+		}
+	}
+	return ""
+}
+
+// listOrgRepos enumerates every repo under org, following pagination.
+func (c *ghClient) listOrgRepos(org string) ([]string, error) {
+	var names []string
+	u := fmt.Sprintf("%s/orgs/%s/repos?per_page=100", githubAPIBase, url.PathEscape(org))
+	for u != "" {
+		var repos []ghRepo
+		res, err := c.getJSON(u, &repos)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range repos {
+			names = append(names, r.Name)
+		}
+		u = nextPageURL(res)
+	}
+	return names, nil
+}
+
+// searchIssues runs a GitHub search query (e.g. "repo:org/name created:since..until")
+// against the issues/PRs search endpoint, following pagination.
+func (c *ghClient) searchIssues(query string) ([]ghIssue, error) {
+	var all []ghIssue
+	u := fmt.Sprintf("%s/search/issues?q=%s&per_page=100", githubAPIBase, url.QueryEscape(query))
+	for u != "" {
+		var page struct {
+			Items []ghIssue `json:"items"`
+		}
+		res, err := c.getJSON(u, &page)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.Items...)
+		u = nextPageURL(res)
+	}
+	return all, nil
+}
+
+// fetchGitHubReport builds the same headers/headerContentDict/userDict shape
+// the goquery path produces, but sourced from the GitHub API. Each repo under
+// org becomes a header; issues/PRs opened or closed in [since, until] become
+// its entries, a Counters and per-user UserStats are tallied as usual, and
+// entries are grouped by label into categoryOrder/categoryDict per
+// cfg.Categories.
+func fetchGitHubReport(org, since, until string) (headers []string, headerContentDict map[string][]Entry, userDict map[string]string, userStats map[string]*UserStats, counters Counters, categoryOrder []string, categoryDict map[string][]Entry) {
+	c := newGHClient()
+
+	repos, err := c.listOrgRepos(org)
+	if err != nil {
+		log.Fatalf("list repos for org <%s> failed: [%v]", org, err)
+	}
+
+	headerContentDict = make(map[string][]Entry)
+	userDict = make(map[string]string)
+	userStats = make(map[string]*UserStats)
+	var labeled []labeledEntry
+
+	for _, repo := range repos {
+		var entries []Entry
+
+		opened, err := c.searchIssues(fmt.Sprintf("repo:%s/%s created:%s..%s", org, repo, since, until))
+		if err != nil {
+			log.Fatalf("search opened issues/prs for repo <%s> failed: [%v]", repo, err)
+		}
+		for _, it := range opened {
+			entries, labeled = appendGHEntry(entries, labeled, userDict, userStats, &counters, it, true)
+		}
+
+		closed, err := c.searchIssues(fmt.Sprintf("repo:%s/%s closed:%s..%s", org, repo, since, until))
+		if err != nil {
+			log.Fatalf("search closed issues/prs for repo <%s> failed: [%v]", repo, err)
+		}
+		for _, it := range closed {
+			entries, labeled = appendGHEntry(entries, labeled, userDict, userStats, &counters, it, false)
+		}
+
+		if len(entries) == 0 {
+			continue
+		}
+		headers = append(headers, repo)
+		headerContentDict[repo] = entries
+	}
+
+	categoryOrder, categoryDict = categorize(cfg.Categories, labeled)
+
+	return headers, headerContentDict, userDict, userStats, counters, categoryOrder, categoryDict
+}
+
+// appendGHEntry records one issue/PR against counters and returns
+// entries/labeled with its Entry appended.
+func appendGHEntry(entries []Entry, labeled []labeledEntry, userDict map[string]string, userStats map[string]*UserStats, counters *Counters, it ghIssue, opened bool) ([]Entry, []labeledEntry) {
+	user := "@" + it.User.Login
+	if isBot(user) {
+		return entries, labeled
+	}
+	if _, ok := userDict[user]; !ok {
+		userDict[user] = it.User.HTMLURL
+	}
+
+	var verb string
+	switch {
+	case opened && it.PullRequest != nil:
+		verb = "opened pull request"
+		counters.PROpen++
+	case opened:
+		verb = "opened issue"
+		counters.IssueOpen++
+	case it.PullRequest != nil && it.PullRequest.MergedAt != nil:
+		verb = "merged pull request"
+		counters.PRClose++
+	case it.PullRequest != nil:
+		verb = "closed pull request"
+		counters.PRClose++
+	default:
+		verb = "closed issue"
+		counters.IssueClose++
+	}
+	recordActivity(userStats, user, verb)
+
+	entry := Entry{User: user, Verb: verb, Title: it.Title, Link: it.HTMLURL}
+
+	labelNames := make([]string, len(it.Labels))
+	for i, l := range it.Labels {
+		labelNames[i] = l.Name
+	}
+	labeled = append(labeled, labeledEntry{entry: entry, labels: labelNames})
+
+	return append(entries, entry), labeled
+}
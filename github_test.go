@@ -0,0 +1,40 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNextPageURL(t *testing.T) {
+	cases := []struct {
+		name string
+		link string
+		want string
+	}{
+		{
+			name: "has next",
+			link: `<https://api.github.com/repositories/1/issues?page=2>; rel="next", <https://api.github.com/repositories/1/issues?page=5>; rel="last"`,
+			want: "https://api.github.com/repositories/1/issues?page=2",
+		},
+		{
+			name: "last page, no next",
+			link: `<https://api.github.com/repositories/1/issues?page=1>; rel="prev", <https://api.github.com/repositories/1/issues?page=1>; rel="first"`,
+			want: "",
+		},
+		{
+			name: "no link header",
+			link: "",
+			want: "",
+		},
+	}
+
+	for _, c := range cases {
+		res := &http.Response{Header: http.Header{}}
+		if c.link != "" {
+			res.Header.Set("Link", c.link)
+		}
+		if got := nextPageURL(res); got != c.want {
+			t.Errorf("%s: nextPageURL() = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// UserStats tallies one user's weekly activity for the Top Contributors
+// leaderboard.
+type UserStats struct {
+	OpenedIssues int
+	ClosedIssues int
+	OpenedPRs    int
+	MergedPRs    int
+}
+
+// Total is the sum of a user's tallied activity, used to sort the
+// leaderboard.
+func (s UserStats) Total() int {
+	return s.OpenedIssues + s.ClosedIssues + s.OpenedPRs + s.MergedPRs
+}
+
+// Add folds other's counters into s, for merging per-week stats in the
+// multi-week crawler.
+func (s *UserStats) Add(other UserStats) {
+	s.OpenedIssues += other.OpenedIssues
+	s.ClosedIssues += other.ClosedIssues
+	s.OpenedPRs += other.OpenedPRs
+	s.MergedPRs += other.MergedPRs
+}
+
+// recordActivity increments the right UserStats counter for user based on
+// verb, one of the phrases recognized by regOpenPR/regClosePR/regOpenIssue/
+// regCloseIssue (the same phrases count() tallies into a Counters).
+func recordActivity(stats map[string]*UserStats, user, verb string) {
+	s, ok := stats[user]
+	if !ok {
+		s = &UserStats{}
+		stats[user] = s
+	}
+	switch {
+	case regOpenPR.MatchString(verb):
+		s.OpenedPRs++
+	case regClosePR.MatchString(verb):
+		// a PR closed without being merged is not counted as a merged PR
+		if strings.Contains(verb, "merged pull request") {
+			s.MergedPRs++
+		}
+	case regOpenIssue.MatchString(verb):
+		s.OpenedIssues++
+	case regCloseIssue.MatchString(verb):
+		s.ClosedIssues++
+	}
+}
+
+// LeaderboardEntry is one row of the Top Contributors table.
+type LeaderboardEntry struct {
+	User  string
+	Stats UserStats
+}
+
+// topContributors sorts stats by total activity descending, breaking ties
+// alphabetically by username so output stays deterministic and diffable
+// across runs, and truncates to the top n (n <= 0 means no limit).
+func topContributors(stats map[string]*UserStats, n int) []LeaderboardEntry {
+	entries := make([]LeaderboardEntry, 0, len(stats))
+	for user, s := range stats {
+		entries = append(entries, LeaderboardEntry{User: user, Stats: *s})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Stats.Total() != entries[j].Stats.Total() {
+			return entries[i].Stats.Total() > entries[j].Stats.Total()
+		}
+		return entries[i].User < entries[j].User
+	})
+
+	if n > 0 && len(entries) > n {
+		entries = entries[:n]
+	}
+	return entries
+}
+
+// categorize groups entries by the first matching rule in categories
+// (matched against an entry's labels), returning the category names in rule
+// order followed by "Other" when it has entries.
+func categorize(categories []CategoryRule, labeled []labeledEntry) (order []string, grouped map[string][]Entry) {
+	grouped = make(map[string][]Entry)
+
+	for _, entry := range labeled {
+		name := "Other"
+		for _, rule := range categories {
+			if hasAnyLabel(entry.labels, rule.Labels) {
+				name = rule.Name
+				break
+			}
+		}
+		grouped[name] = append(grouped[name], entry.entry)
+	}
+
+	seen := make(map[string]bool)
+	for _, rule := range categories {
+		if _, ok := grouped[rule.Name]; ok && !seen[rule.Name] {
+			seen[rule.Name] = true
+			order = append(order, rule.Name)
+		}
+	}
+	if _, ok := grouped["Other"]; ok && !seen["Other"] {
+		order = append(order, "Other")
+	}
+
+	return order, grouped
+}
+
+// labeledEntry pairs an Entry with the labels of the issue/PR it came from,
+// for categorize to group on.
+type labeledEntry struct {
+	entry  Entry
+	labels []string
+}
+
+// hasAnyLabel reports whether labels and wanted share at least one entry.
+func hasAnyLabel(labels, wanted []string) bool {
+	for _, l := range labels {
+		for _, w := range wanted {
+			if l == w {
+				return true
+			}
+		}
+	}
+	return false
+}
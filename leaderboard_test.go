@@ -0,0 +1,38 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTopContributorsTieBreak(t *testing.T) {
+	stats := map[string]*UserStats{
+		"@bob":   {OpenedIssues: 2}, // total 2
+		"@alice": {OpenedIssues: 2}, // total 2, ties with @bob, should sort first alphabetically
+		"@zed":   {OpenedIssues: 5}, // total 5, should sort first overall
+	}
+
+	got := topContributors(stats, 0)
+
+	want := []string{"@zed", "@alice", "@bob"}
+	var gotUsers []string
+	for _, entry := range got {
+		gotUsers = append(gotUsers, entry.User)
+	}
+	if !reflect.DeepEqual(gotUsers, want) {
+		t.Errorf("topContributors order = %v, want %v", gotUsers, want)
+	}
+}
+
+func TestTopContributorsLimit(t *testing.T) {
+	stats := map[string]*UserStats{
+		"@a": {OpenedIssues: 3},
+		"@b": {OpenedIssues: 2},
+		"@c": {OpenedIssues: 1},
+	}
+
+	got := topContributors(stats, 2)
+	if len(got) != 2 {
+		t.Errorf("topContributors(stats, 2) returned %d entries, want 2", len(got))
+	}
+}
@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
 	"regexp"
+	"runtime"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
@@ -17,9 +19,31 @@ import (
 // communityPrefix used to combine the url with repo name
 const communityPrefix = "https://github.com/beyondstorage/"
 
-// global variables for statistic
-// use global variable may be not a good idea, but the simplest way :)
-var issueOpen, issueClose, prOpen, prClose int
+// Counters tallies the Weekly Stats totals. It's threaded through explicitly
+// (rather than kept as package globals) so concurrent callers, like the
+// multi-week crawler's worker pool, can tally per-call and merge afterwards
+// instead of racing on shared state.
+type Counters struct {
+	IssueOpen  int
+	IssueClose int
+	PROpen     int
+	PRClose    int
+}
+
+// Add folds other's counts into c, for merging per-week counters in the
+// multi-week crawler.
+func (c *Counters) Add(other Counters) {
+	c.IssueOpen += other.IssueOpen
+	c.IssueClose += other.IssueClose
+	c.PROpen += other.PROpen
+	c.PRClose += other.PRClose
+}
+
+// cfg holds the loaded --config, or defaultConfig() when absent.
+var cfg = defaultConfig()
+
+// configPath is the --config flag.
+var configPath string
 
 // pre-compile regexp when build
 var regClosePR = regexp.MustCompile("merged pull request|closed pull request")
@@ -30,23 +54,68 @@ var regCloseIssue = regexp.MustCompile("closed issue")
 // two flags for weekly report
 var inputPath, outputPath string
 
+// flags for the github API source
+var sourceFlag, orgFlag, sinceFlag, untilFlag string
+
+// flags for output rendering
+var formatFlag, templateFlag string
+
+// topN is the --top flag, the number of users shown in the Top Contributors
+// leaderboard.
+var topN int
+
 var rootCmd = &cobra.Command{
 	Use:   "report-script",
 	Short: "report-script generate the predefined format report from BeyondStorage weekly report",
 	Example: `  generate report to stdout:     report-script --input "https://url/to/report"
   generate report to file:       report-script --input "https://url/to/report" --output path/to/file
-  generate report by local file: report-script --input path/to/input`,
+  generate report by local file: report-script --input path/to/input
+  generate report from github:   report-script --source github --org beyondstorage --since 2024-01-01 --until 2024-01-07`,
 	Version: "0.1.0",
 	Run: func(cmd *cobra.Command, args []string) {
+		loaded, err := loadConfig(configPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg = loaded
+
+		if sourceFlag == "github" {
+			if sinceFlag == "" || untilFlag == "" {
+				log.Fatal(`--source github requires both --since and --until`)
+			}
+			generateReportFromGitHub(orgFlag, sinceFlag, untilFlag, outputPath)
+			return
+		}
+		if inputPath == "" {
+			log.Fatal(`--input is required unless --source github is set`)
+		}
+		if fromWeek != "" || toWeek != "" {
+			if fromWeek == "" || toWeek == "" {
+				log.Fatal(`--from and --to must be set together`)
+			}
+			generateRangeReport(inputPath, fromWeek, toWeek, outputPath)
+			return
+		}
 		generateReport(inputPath, outputPath)
 	},
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVar(&inputPath, "input", "", "input for BeyondStorage weekly report, url or local path")
+	rootCmd.PersistentFlags().StringVar(&inputPath, "input", "", `input for BeyondStorage weekly report, url or local path; with --from/--to, a template containing "{week}"`)
 	rootCmd.PersistentFlags().StringVar(&outputPath, "output", "", "output for formatted report, if blank, use stdout instead")
-	// mark input flag required
-	rootCmd.MarkPersistentFlagRequired("input")
+	rootCmd.PersistentFlags().StringVar(&sourceFlag, "source", "wiki", `report source, "wiki" (default, scrapes the weekly report page) or "github" (queries the GitHub API directly)`)
+	rootCmd.PersistentFlags().StringVar(&orgFlag, "org", "beyondstorage", `github org to enumerate repos from, only used when --source github`)
+	rootCmd.PersistentFlags().StringVar(&sinceFlag, "since", "", `only used when --source github, inclusive start date (YYYY-MM-DD)`)
+	rootCmd.PersistentFlags().StringVar(&untilFlag, "until", "", `only used when --source github, inclusive end date (YYYY-MM-DD)`)
+	rootCmd.PersistentFlags().StringVar(&fromWeek, "from", "", "first week (YYYY-MM-DD) to crawl, use with --to for a multi-week report")
+	rootCmd.PersistentFlags().StringVar(&toWeek, "to", "", "last week (YYYY-MM-DD) to crawl, use with --from for a multi-week report")
+	rootCmd.PersistentFlags().IntVar(&concurrency, "concurrency", runtime.GOMAXPROCS(0), "number of weekly reports to fetch in parallel, only used with --from/--to")
+	rootCmd.PersistentFlags().BoolVar(&noProgress, "no-progress", false, "suppress the progress bar when crawling a --from/--to range")
+	rootCmd.PersistentFlags().BoolVar(&silent, "silent", false, "suppress the progress bar and per-week fetch errors when crawling a --from/--to range")
+	rootCmd.PersistentFlags().StringVar(&formatFlag, "format", "markdown", "output format: markdown, json, html or hugo")
+	rootCmd.PersistentFlags().StringVar(&templateFlag, "template", "", "path to a custom text/template, overrides --format when set")
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "path to a YAML or TOML config overriding the community prefix, bot filter, repo links and category rules")
+	rootCmd.PersistentFlags().IntVar(&topN, "top", 10, "number of users to show in the Top Contributors leaderboard, 0 for no limit")
 }
 
 func generateReport(input, output string) {
@@ -61,49 +130,82 @@ func generateReport(input, output string) {
 		writer = f
 	}
 
-	var reader io.Reader
-	// if input start with http or https, handle as url
-	// otherwise, handle as local file (because sometimes the network may not work as intended)
+	reader, err := openInput(input)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if closer, ok := reader.(interface{ Close() error }); ok {
+		defer closer.Close()
+	}
+
+	headers, headerContentDict, userDict, userStats, counters, err := parseWikiReport(reader)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	renderReport(writer, buildReport(headers, headerContentDict, userDict, userStats, counters, nil, nil))
+}
+
+// openInput opens input as an io.Reader: a GET request if it looks like a
+// URL, otherwise a local file (because sometimes the network may not work
+// as intended).
+func openInput(input string) (io.Reader, error) {
+	return openInputCtx(context.Background(), input)
+}
+
+// openInputCtx is openInput with ctx wired into the HTTP request, so a
+// caller holding a cancellable context (the multi-week crawler, on SIGINT)
+// can actually abort a GET that's already in flight rather than only
+// skipping requests that haven't started yet.
+func openInputCtx(ctx context.Context, input string) (io.Reader, error) {
 	if strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://") {
-		// Request the HTML page.
-		res, err := http.Get(input)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, input, nil)
 		if err != nil {
-			log.Fatal(err)
+			return nil, err
 		}
-		defer res.Body.Close()
-		if res.StatusCode != 200 {
-			log.Fatalf("status code error: %d %s", res.StatusCode, res.Status)
-		}
-		reader = res.Body
-	} else {
-		res, err := os.Open(input)
+		res, err := http.DefaultClient.Do(req)
 		if err != nil {
-			log.Fatal(err)
+			return nil, err
 		}
-		defer res.Close()
-		reader = res
+		if res.StatusCode != 200 {
+			res.Body.Close()
+			return nil, fmt.Errorf("status code error: %d %s", res.StatusCode, res.Status)
+		}
+		return res.Body, nil
 	}
 
+	return os.Open(input)
+}
+
+// parseWikiReport parses a single weekly-report page (scraped HTML, Gitea
+// wiki style) into the same headers/headerContentDict/userDict shape used
+// across the tool, tallying a Counters and per-user UserStats as it goes.
+// Labels aren't available from the scraped page, so category grouping is
+// left to the GitHub source.
+func parseWikiReport(reader io.Reader) (headers []string, headerContentDict map[string][]Entry, userDict map[string]string, userStats map[string]*UserStats, counters Counters, err error) {
 	// Load the HTML document
 	doc, err := goquery.NewDocumentFromReader(reader)
 	if err != nil {
-		log.Fatal(err)
+		return nil, nil, nil, nil, Counters{}, err
 	}
 
 	// init user-link dict
 	// key-value like:
 	//   @username1: url/to/username1
 	//   @username2: url/to/username2
-	userDict := make(map[string]string)
+	userDict = make(map[string]string)
 
 	// init header-content dict
 	// key-value like:
 	//   go-storage: [@user opened issue xxx, @user merged PR request]
 	//   go-service-fs: [@user opened PR request, @user closed issue]
-	headerContentDict := make(map[string][]string)
+	headerContentDict = make(map[string][]Entry)
 
 	// headers defined as slice to keep headers sequential
-	headers := make([]string, 0)
+	headers = make([]string, 0)
+
+	// per-user tallies for the Top Contributors leaderboard
+	userStats = make(map[string]*UserStats)
 
 	// location the report element in html document
 	doc.Find("td.comment-body").Children().Each(func(i int, s *goquery.Selection) {
@@ -116,7 +218,7 @@ func generateReport(input, output string) {
 			// 	return
 			// }
 			headers = append(headers, aNode.Text())
-			headerContentDict[aNode.Text()] = make([]string, 0)
+			headerContentDict[aNode.Text()] = make([]Entry, 0)
 			// fmt.Fprintf(writer, "\n## [%s](%s)\n", aNode.Text(), attr)
 			return
 		}
@@ -149,7 +251,8 @@ func generateReport(input, output string) {
 				}
 
 				// add counter by text data
-				count(userText.Data)
+				count(&counters, userText.Data)
+				recordActivity(userStats, userNode.Text(), userText.Data)
 
 				// location the issue node
 				issueNode := liNode.Children().ChildrenFiltered("a")
@@ -169,71 +272,83 @@ func generateReport(input, output string) {
 
 				// headers' last element is the current header, got current header's list
 				list := headerContentDict[headers[len(headers)-1]]
-				list = append(list, fmt.Sprintf("[%s]%s[%s](%s)", userNode.Text(), userText.Data, issueText.Data, issueLink))
+				list = append(list, Entry{User: userNode.Text(), Verb: userText.Data, Title: issueText.Data, Link: issueLink})
 				headerContentDict[headers[len(headers)-1]] = list
 			})
 		}
 	})
 
-	// now start writing to output
-	// print weekly stats
-	fmt.Fprintf(writer, `
-## Weekly Stats
-
-| | Opened this week | Closed this week |
-| ---- | ---- | ---- |
-| Issues | %d | %d |
-| PR's | %d | %d |
-`, issueOpen, issueClose, prOpen, prClose)
-
-	fmt.Fprintf(writer, "\n") // add blank line
+	return headers, headerContentDict, userDict, userStats, counters, nil
+}
 
-	// print header and content
-	for _, header := range headers {
-		// skip headers without contents
-		if len(headerContentDict[header]) == 0 {
-			continue
-		}
-		// example: "## [go-storage](https://github.com/beyondstorage/go-storage)"
-		fmt.Fprintf(writer, "## [%s](%s%s)\n", header, communityPrefix, header)
-		fmt.Fprintf(writer, "\n") // add blank line
-		for _, content := range headerContentDict[header] {
-			// example: "- [@username] opened an issue [issue name](issue url)\n"
-			fmt.Fprintf(writer, "- %s\n", content)
+// generateReportFromGitHub builds a report the same shape as generateReport,
+// but sourced from the GitHub API instead of a scraped wiki page.
+func generateReportFromGitHub(org, since, until, output string) {
+	var writer io.Writer
+	if output == "" {
+		writer = os.Stdout
+	} else {
+		f, err := os.Create(output)
+		if err != nil {
+			log.Fatalf("create output file <%s> failed: [%v]", output, err)
 		}
-		fmt.Fprintf(writer, "\n") // add blank line
+		writer = f
 	}
 
-	fmt.Fprintf(writer, "\n") // add blank line
+	headers, headerContentDict, userDict, userStats, counters, categoryOrder, categoryDict := fetchGitHubReport(org, since, until)
+	renderReport(writer, buildReport(headers, headerContentDict, userDict, userStats, counters, categoryOrder, categoryDict))
+}
 
-	// print user-link map
-	for user, link := range userDict {
-		fmt.Fprintf(writer, "[%s]: %s\n", user, link)
+// buildReport packages the aggregated headers/content/user dicts, counters,
+// and the per-user leaderboard/label categories (categoryOrder/categoryDict
+// may be nil when the source has no label data) into a Report for a
+// Renderer to consume.
+func buildReport(headers []string, headerContentDict map[string][]Entry, userDict map[string]string, userStats map[string]*UserStats, counters Counters, categoryOrder []string, categoryDict map[string][]Entry) *Report {
+	return &Report{
+		IssueOpen:     counters.IssueOpen,
+		IssueClose:    counters.IssueClose,
+		PROpen:        counters.PROpen,
+		PRClose:       counters.PRClose,
+		Headers:       headers,
+		Entries:       headerContentDict,
+		Users:         userDict,
+		Leaderboard:   topContributors(userStats, topN),
+		CategoryOrder: categoryOrder,
+		Categories:    categoryDict,
 	}
 }
 
-func count(content string) {
+// renderReport looks up the Renderer selected by --format/--template and
+// writes r with it, exiting on failure.
+func renderReport(w io.Writer, r *Report) {
+	render, err := rendererFor(formatFlag, templateFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := render.Render(w, r); err != nil {
+		log.Fatalf("render report failed: [%v]", err)
+	}
+}
+
+// count tallies content (one of the phrases regOpenPR/regClosePR/regOpenIssue/
+// regCloseIssue recognize) into counters.
+func count(counters *Counters, content string) {
 	switch {
 	case regOpenPR.MatchString(content):
-		prOpen++
+		counters.PROpen++
 	case regClosePR.MatchString(content):
-		prClose++
+		counters.PRClose++
 	case regOpenIssue.MatchString(content):
-		issueOpen++
+		counters.IssueOpen++
 	case regCloseIssue.MatchString(content):
-		issueClose++
+		counters.IssueClose++
 	}
 }
 
-// isBot check whether a user is robot
-// for now, we only introduced two robots: dependabot, BeyondRobot
+// isBot check whether a user is robot, per the loaded config's bot list
+// (defaultConfig's @dependabot/@BeyondRobot when --config is not set).
 func isBot(name string) bool {
-	switch name {
-	case "@dependabot", "@BeyondRobot":
-		return true
-	default:
-		return false
-	}
+	return cfg.isBot(name)
 }
 
 func main() {
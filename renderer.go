@@ -0,0 +1,241 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Entry is one issue/PR activity line: user did verb to an issue/PR titled
+// Title at Link. Renderers format it however suits their output instead of
+// all sharing one pre-formatted markdown string.
+type Entry struct {
+	User  string
+	Verb  string
+	Title string
+	Link  string
+}
+
+// Report is the aggregated data a Renderer turns into output, regardless of
+// whether it came from the goquery wiki scraper, the GitHub API, or a
+// multi-week crawl.
+type Report struct {
+	IssueOpen  int
+	IssueClose int
+	PROpen     int
+	PRClose    int
+	Headers    []string
+	Entries    map[string][]Entry
+	Users      map[string]string
+
+	// Leaderboard is the --top Top Contributors ranking, sorted by total
+	// activity then alphabetically by username.
+	Leaderboard []LeaderboardEntry
+
+	// CategoryOrder lists Categories' keys in the order they should render;
+	// Categories itself is nil when the source has no label data.
+	CategoryOrder []string
+	Categories    map[string][]Entry
+}
+
+// Renderer turns a Report into formatted output.
+type Renderer interface {
+	Render(w io.Writer, r *Report) error
+}
+
+// renderers holds the built-in Renderers, selectable with --format.
+var renderers = map[string]Renderer{
+	"markdown": markdownRenderer{},
+	"json":     jsonRenderer{},
+	"html":     htmlRenderer{},
+	"hugo":     hugoRenderer{},
+}
+
+// rendererFor picks the Renderer for format, or wraps templatePath into a
+// templateRenderer when one is given (--template overrides --format).
+func rendererFor(format, templatePath string) (Renderer, error) {
+	if templatePath != "" {
+		return newTemplateRenderer(templatePath)
+	}
+	r, ok := renderers[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown --format %q", format)
+	}
+	return r, nil
+}
+
+// markdownRenderer emits report-script's original markdown layout.
+type markdownRenderer struct{}
+
+func (markdownRenderer) Render(w io.Writer, r *Report) error {
+	fmt.Fprintf(w, `
+## Weekly Stats
+
+| | Opened this week | Closed this week |
+| ---- | ---- | ---- |
+| Issues | %d | %d |
+| PR's | %d | %d |
+`, r.IssueOpen, r.IssueClose, r.PROpen, r.PRClose)
+
+	fmt.Fprintf(w, "\n") // add blank line
+
+	for _, header := range r.Headers {
+		// skip headers without contents
+		if len(r.Entries[header]) == 0 {
+			continue
+		}
+		// example: "## [go-storage](https://github.com/beyondstorage/go-storage)"
+		fmt.Fprintf(w, "## [%s](%s)\n", header, cfg.repoLink(header))
+		fmt.Fprintf(w, "\n") // add blank line
+		for _, entry := range r.Entries[header] {
+			// example: "- [@username] opened an issue [issue name](issue url)\n"
+			fmt.Fprintf(w, "- [%s] %s [%s](%s)\n", entry.User, strings.TrimSpace(entry.Verb), entry.Title, entry.Link)
+		}
+		fmt.Fprintf(w, "\n") // add blank line
+	}
+
+	if len(r.Leaderboard) > 0 {
+		fmt.Fprintf(w, "## Top Contributors\n\n")
+		fmt.Fprintf(w, "| User | Opened Issues | Closed Issues | Opened PRs | Merged PRs | Total |\n")
+		fmt.Fprintf(w, "| ---- | ---- | ---- | ---- | ---- | ---- |\n")
+		for _, entry := range r.Leaderboard {
+			fmt.Fprintf(w, "| %s | %d | %d | %d | %d | %d |\n",
+				entry.User, entry.Stats.OpenedIssues, entry.Stats.ClosedIssues,
+				entry.Stats.OpenedPRs, entry.Stats.MergedPRs, entry.Stats.Total())
+		}
+		fmt.Fprintf(w, "\n") // add blank line
+	}
+
+	for _, category := range r.CategoryOrder {
+		fmt.Fprintf(w, "## %s\n\n", category)
+		for _, entry := range r.Categories[category] {
+			fmt.Fprintf(w, "- [%s] %s [%s](%s)\n", entry.User, strings.TrimSpace(entry.Verb), entry.Title, entry.Link)
+		}
+		fmt.Fprintf(w, "\n") // add blank line
+	}
+
+	for user, link := range r.Users {
+		fmt.Fprintf(w, "[%s]: %s\n", user, link)
+	}
+	return nil
+}
+
+// jsonRenderer emits the raw aggregated Report so downstream tools can
+// consume it without re-parsing markdown.
+type jsonRenderer struct{}
+
+func (jsonRenderer) Render(w io.Writer, r *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// htmlRenderer emits a self-contained HTML page with a stats table and one
+// section per repo/header.
+type htmlRenderer struct{}
+
+func (htmlRenderer) Render(w io.Writer, r *Report) error {
+	fmt.Fprintf(w, `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Weekly Report</title></head>
+<body>
+<h2>Weekly Stats</h2>
+<table border="1">
+<tr><th></th><th>Opened this week</th><th>Closed this week</th></tr>
+<tr><td>Issues</td><td>%d</td><td>%d</td></tr>
+<tr><td>PR's</td><td>%d</td><td>%d</td></tr>
+</table>
+`, r.IssueOpen, r.IssueClose, r.PROpen, r.PRClose)
+
+	for _, header := range r.Headers {
+		if len(r.Entries[header]) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "<h2><a href=\"%s\">%s</a></h2>\n<ul>\n", cfg.repoLink(header), html.EscapeString(header))
+		for _, entry := range r.Entries[header] {
+			fmt.Fprintf(w, "<li>%s</li>\n", entryHTML(entry, r.Users[entry.User]))
+		}
+		fmt.Fprintf(w, "</ul>\n")
+	}
+
+	if len(r.Leaderboard) > 0 {
+		fmt.Fprintf(w, `<h2>Top Contributors</h2>
+<table border="1">
+<tr><th>User</th><th>Opened Issues</th><th>Closed Issues</th><th>Opened PRs</th><th>Merged PRs</th><th>Total</th></tr>
+`)
+		for _, entry := range r.Leaderboard {
+			fmt.Fprintf(w, "<tr><td>%s</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td></tr>\n",
+				html.EscapeString(entry.User), entry.Stats.OpenedIssues, entry.Stats.ClosedIssues,
+				entry.Stats.OpenedPRs, entry.Stats.MergedPRs, entry.Stats.Total())
+		}
+		fmt.Fprintf(w, "</table>\n")
+	}
+
+	for _, category := range r.CategoryOrder {
+		fmt.Fprintf(w, "<h2>%s</h2>\n<ul>\n", html.EscapeString(category))
+		for _, entry := range r.Categories[category] {
+			fmt.Fprintf(w, "<li>%s</li>\n", entryHTML(entry, r.Users[entry.User]))
+		}
+		fmt.Fprintf(w, "</ul>\n")
+	}
+
+	fmt.Fprintf(w, "</body>\n</html>\n")
+	return nil
+}
+
+// entryHTML renders entry as "<a href="userLink">@user</a> verb <a
+// href="entry link">title</a>", HTML-escaping each text field individually
+// so the issue/PR title links out instead of printing as literal markdown.
+// userLink is blank when the user has no known profile URL, in which case
+// the username renders as plain text.
+func entryHTML(entry Entry, userLink string) string {
+	user := html.EscapeString(entry.User)
+	if userLink != "" {
+		user = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(userLink), user)
+	}
+	return fmt.Sprintf(`%s %s <a href="%s">%s</a>`,
+		user, html.EscapeString(entry.Verb),
+		html.EscapeString(entry.Link), html.EscapeString(entry.Title))
+}
+
+// hugoRenderer prepends Hugo/Jekyll style YAML front-matter to the markdown
+// layout, so the output can drop straight into a static site's content/ dir.
+type hugoRenderer struct{}
+
+func (hugoRenderer) Render(w io.Writer, r *Report) error {
+	fmt.Fprintf(w, `---
+title: "Weekly Report"
+date: %s
+tags: [weekly-report]
+---
+`, time.Now().Format("2006-01-02"))
+	return markdownRenderer{}.Render(w, r)
+}
+
+// templateRenderer executes a user-supplied text/template against the
+// Report, for full customization beyond the built-in formats.
+type templateRenderer struct {
+	tmpl *template.Template
+}
+
+// newTemplateRenderer parses the template at path for use as a Renderer.
+func newTemplateRenderer(path string) (Renderer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read --template <%s> failed: [%w]", path, err)
+	}
+	tmpl, err := template.New(path).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("parse --template <%s> failed: [%w]", path, err)
+	}
+	return templateRenderer{tmpl: tmpl}, nil
+}
+
+func (t templateRenderer) Render(w io.Writer, r *Report) error {
+	return t.tmpl.Execute(w, r)
+}